@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"logger/sink"
+)
+
+// OverflowPolicy controls what happens to a log record when the async
+// queue is full.
+type OverflowPolicy int
+
+const (
+	// Block waits for room in the queue, applying backpressure to the
+	// caller.
+	Block OverflowPolicy = iota
+	// DropNewest discards the record that didn't fit.
+	DropNewest
+	// DropOldest discards the oldest queued record to make room.
+	DropOldest
+)
+
+const (
+	defaultBufferSize  = 1024
+	dropReportInterval = 5 * time.Second
+)
+
+// queueItem is either a log record to dispatch, or (when done is set) a
+// flush barrier: once the worker goroutine reaches it, every record
+// enqueued before it has been dispatched, so closing done signals Flush
+// callers that the drain is complete.
+type queueItem struct {
+	rec  Record
+	done chan struct{}
+}
+
+// startAsync wires up the queue and worker goroutines used when
+// LoggerOptions.Async is set.
+func (l *Logger) startAsync(bufferSize int, overflow OverflowPolicy) {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	core := l.core
+	core.async = true
+	core.overflow = overflow
+	core.queue = make(chan queueItem, bufferSize)
+	core.done = make(chan struct{})
+
+	core.wg.Add(2)
+	go l.drainLoop()
+	go l.reportDroppedLoop()
+}
+
+// drainLoop is the single consumer of l.core.queue; it dispatches
+// records to the sinks in the order they were enqueued. core.queue is
+// never closed (see enqueue), so this drains it until core.done fires
+// and then exits once nothing is left to dispatch, rather than ranging
+// over the channel until a close.
+func (l *Logger) drainLoop() {
+	defer l.core.wg.Done()
+	for {
+		select {
+		case item := <-l.core.queue:
+			l.dispatchItem(item)
+		case <-l.core.done:
+			l.drainRemaining()
+			return
+		}
+	}
+}
+
+// drainRemaining dispatches whatever is left in the queue without
+// blocking, for the final drain after core.done fires.
+func (l *Logger) drainRemaining() {
+	for {
+		select {
+		case item := <-l.core.queue:
+			l.dispatchItem(item)
+		default:
+			return
+		}
+	}
+}
+
+func (l *Logger) dispatchItem(item queueItem) {
+	if item.done != nil {
+		close(item.done)
+		return
+	}
+	l.dispatch(item.rec)
+}
+
+// enqueue adds rec to the queue, applying the configured OverflowPolicy
+// if it is full. The Block branch selects on core.done alongside the
+// send so a producer blocked here when Close runs is released instead
+// of leaving Close to close a channel out from under it.
+func (l *Logger) enqueue(rec Record) {
+	core := l.core
+	item := queueItem{rec: rec}
+
+	select {
+	case core.queue <- item:
+		return
+	default:
+	}
+
+	switch core.overflow {
+	case DropNewest:
+		core.dropped.Add(1)
+	case DropOldest:
+		select {
+		case <-core.queue:
+		default:
+		}
+		select {
+		case core.queue <- item:
+		default:
+			core.dropped.Add(1)
+		}
+	default: // Block
+		select {
+		case core.queue <- item:
+		case <-core.done:
+			core.dropped.Add(1)
+		}
+	}
+}
+
+// reportDroppedLoop periodically emits a "dropped N messages" record so
+// that silent loss under DropNewest/DropOldest stays visible.
+func (l *Logger) reportDroppedLoop() {
+	defer l.core.wg.Done()
+
+	ticker := time.NewTicker(dropReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.emitDropReport()
+		case <-l.core.done:
+			l.emitDropReport()
+			return
+		}
+	}
+}
+
+func (l *Logger) emitDropReport() {
+	n := l.core.dropped.Swap(0)
+	if n == 0 {
+		return
+	}
+	l.dispatch(Record{
+		Timestamp: time.Now(),
+		Level:     sink.Level(WARN),
+		Caller:    "logger",
+		Message:   fmt.Sprintf("dropped %d messages", n),
+	})
+}
+
+// Flush blocks until every record enqueued before the call has been
+// dispatched to the sinks, or ctx is done. It is a no-op for a logger
+// that was not created with Async.
+func (l *Logger) Flush(ctx context.Context) error {
+	if !l.core.async {
+		return nil
+	}
+
+	done := make(chan struct{})
+	select {
+	case l.core.queue <- queueItem{done: done}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.core.done:
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
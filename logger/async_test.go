@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"logger/sink"
+)
+
+// TestWithSharesCore verifies that a child logger obtained from With()
+// dispatches through the same async queue, sampler and dedup as its
+// parent, rather than reverting to independent (and, for async loggers,
+// synchronous) dispatch because With() only copied a subset of fields.
+func TestWithSharesCore(t *testing.T) {
+	l, err := NewLogger(LoggerOptions{MinLevel: INFO, UseSyslog: false})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	child := l.With("component", "test")
+	if child.core != l.core {
+		t.Fatal("With() child does not share the parent's core; it would dispatch independently of the parent's async queue, sampler and dedup")
+	}
+}
+
+// TestWithSharesLevelGate verifies that a child logger obtained from
+// With() shares the parent's *sink.LevelGate rather than cloning it, so
+// a SetLevel call made on either the parent or the child after With()
+// affects both (and the sinks they share).
+func TestWithSharesLevelGate(t *testing.T) {
+	l, err := NewLogger(LoggerOptions{MinLevel: INFO, UseSyslog: false})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	child := l.With("component", "test")
+
+	l.SetLevel(DEBUG)
+	if got := child.Level(); got != DEBUG {
+		t.Fatalf("child.Level() = %v after parent.SetLevel(DEBUG), want DEBUG; With() must share the parent's LevelGate, not clone it", got)
+	}
+
+	child.SetLevel(WARN)
+	if got := l.Level(); got != WARN {
+		t.Fatalf("parent.Level() = %v after child.SetLevel(WARN), want WARN", got)
+	}
+}
+
+// TestCloseIsIdempotent verifies that calling Close more than once on
+// the same logger (a normal idiom: defer l.Close() plus an explicit
+// Close on an error path) neither panics nor hangs, and returns the
+// same result every time.
+func TestCloseIsIdempotent(t *testing.T) {
+	l, err := NewLogger(LoggerOptions{
+		MinLevel:  DEBUG,
+		UseSyslog: false,
+		Async:     true,
+	})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	l.Info("hello")
+
+	done := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() { done <- l.Close() }()
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("concurrent Close calls did not all return; a repeat call is hanging")
+		}
+	}
+}
+
+// TestBlockOverflowSurvivesConcurrentClose exercises the Block overflow
+// policy racing a concurrent Close: a producer that is still enqueueing
+// records must not panic with "send on closed channel" when Close tears
+// down the queue.
+func TestBlockOverflowSurvivesConcurrentClose(t *testing.T) {
+	var buf bytes.Buffer
+	gate := sink.NewLevelGate(sink.Level(DEBUG))
+	console := sink.NewConsole(sink.ConsoleConfig{MinLevel: gate, Encoder: TextEncoder{}, Writer: &buf})
+
+	l, err := NewLogger(LoggerOptions{
+		MinLevel:       DEBUG,
+		Sinks:          []sink.Sink{console},
+		Async:          true,
+		BufferSize:     1,
+		OverflowPolicy: Block,
+	})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	stop := make(chan struct{})
+	panicked := make(chan any, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				panicked <- r
+			}
+		}()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				l.Info("spam")
+			}
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	close(stop)
+	wg.Wait()
+
+	select {
+	case r := <-panicked:
+		t.Fatalf("producer goroutine panicked during concurrent Close: %v", r)
+	default:
+	}
+}
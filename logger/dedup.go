@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"fmt"
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"logger/sink"
+)
+
+// dedupRingSize bounds the number of distinct (level, caller, format)
+// keys tracked concurrently; like sampler, keys hash into this small
+// fixed-size ring rather than a map.
+const dedupRingSize = 512
+
+// DedupConfig configures Dedup: identical repeats of the same (level,
+// caller, format) are coalesced into a single "... (repeated N times)"
+// line, flushed every Tick and whenever a different message arrives in
+// the same slot.
+type DedupConfig struct {
+	Tick time.Duration
+}
+
+type dedupSlot struct {
+	mu      sync.Mutex
+	active  bool
+	key     string
+	level   LogLevel
+	caller  string
+	message string
+	fields  []Field
+	repeats int64
+}
+
+// dedup implements DedupConfig's coalescing. out is called to emit the
+// synthesized "repeated N times" record through the logger's normal
+// dispatch path.
+type dedup struct {
+	seed      maphash.Seed
+	slots     [dedupRingSize]dedupSlot
+	out       func(rec Record)
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+func newDedup(cfg DedupConfig, out func(rec Record)) *dedup {
+	tick := cfg.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	d := &dedup{
+		seed: maphash.MakeSeed(),
+		out:  out,
+		stop: make(chan struct{}),
+	}
+
+	d.wg.Add(1)
+	go d.flushLoop(tick)
+
+	return d
+}
+
+func (d *dedup) bucketIndex(key string) uint64 {
+	var h maphash.Hash
+	h.SetSeed(d.seed)
+	h.WriteString(key)
+	return h.Sum64() % dedupRingSize
+}
+
+// observe records one occurrence of message/fields under key and
+// reports whether the caller should emit it now. A key that matches the
+// slot's current occupant is coalesced (false); a new or different key
+// evicts whatever was there (flushing it first) and is emitted
+// immediately (true).
+func (d *dedup) observe(level LogLevel, caller, key, message string, fields []Field) bool {
+	slot := &d.slots[d.bucketIndex(key)]
+
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+
+	if slot.active && slot.key == key {
+		slot.repeats++
+		return false
+	}
+
+	d.flushSlotLocked(slot)
+
+	slot.active = true
+	slot.key = key
+	slot.level = level
+	slot.caller = caller
+	slot.message = message
+	slot.fields = fields
+	slot.repeats = 0
+	return true
+}
+
+// flushSlotLocked emits a "message (repeated N times)" record for slot
+// if it holds suppressed repeats, then marks it empty. Callers must hold
+// slot.mu.
+func (d *dedup) flushSlotLocked(slot *dedupSlot) {
+	if !slot.active || slot.repeats == 0 {
+		slot.active = false
+		return
+	}
+
+	d.out(Record{
+		Timestamp: time.Now(),
+		Level:     sink.Level(slot.level),
+		Caller:    slot.caller,
+		Message:   fmt.Sprintf("%s (repeated %d times)", slot.message, slot.repeats),
+		Fields:    slot.fields,
+	})
+	slot.active = false
+}
+
+func (d *dedup) flushLoop(tick time.Duration) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.flushAll()
+		case <-d.stop:
+			d.flushAll()
+			return
+		}
+	}
+}
+
+func (d *dedup) flushAll() {
+	for i := range d.slots {
+		slot := &d.slots[i]
+		slot.mu.Lock()
+		d.flushSlotLocked(slot)
+		slot.mu.Unlock()
+	}
+}
+
+// close stops the flush loop and waits for it to exit, flushing any
+// pending repeats first. Safe to call more than once; only the first
+// call does anything.
+func (d *dedup) close() {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+		d.wg.Wait()
+	})
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDedupCoalescesRepeats verifies that repeated occurrences of the
+// same key are suppressed (observe returns false) and later flush as a
+// single "repeated N times" record.
+func TestDedupCoalescesRepeats(t *testing.T) {
+	var emitted []Record
+	d := newDedup(DedupConfig{Tick: time.Hour}, func(rec Record) {
+		emitted = append(emitted, rec)
+	})
+
+	if ok := d.observe(INFO, "caller", "same", "boom", nil); !ok {
+		t.Fatal("first occurrence should be emitted immediately")
+	}
+	for i := 0; i < 3; i++ {
+		if ok := d.observe(INFO, "caller", "same", "boom", nil); ok {
+			t.Fatalf("repeat %d should be coalesced, not emitted", i)
+		}
+	}
+
+	d.close()
+
+	if len(emitted) != 1 {
+		t.Fatalf("expected exactly one flushed record, got %d: %v", len(emitted), emitted)
+	}
+	if want := "boom (repeated 3 times)"; emitted[0].Message != want {
+		t.Fatalf("flushed message = %q, want %q", emitted[0].Message, want)
+	}
+}
+
+// TestDedupDifferentKeyInSameSlotEvicts verifies that when two distinct
+// keys land in the same ring slot, a new key flushes whatever repeats
+// were suppressed for the slot's previous occupant before taking it
+// over, instead of silently discarding them.
+func TestDedupDifferentKeyInSameSlotEvicts(t *testing.T) {
+	var emitted []Record
+	d := newDedup(DedupConfig{Tick: time.Hour}, func(rec Record) {
+		emitted = append(emitted, rec)
+	})
+	defer d.close()
+
+	slot := &d.slots[d.bucketIndex("incoming")]
+	slot.mu.Lock()
+	slot.active = true
+	slot.key = "previous"
+	slot.level = WARN
+	slot.caller = "caller"
+	slot.message = "old message"
+	slot.repeats = 5
+	slot.mu.Unlock()
+
+	if ok := d.observe(INFO, "caller", "incoming", "new message", nil); !ok {
+		t.Fatal("a key that evicts another slot occupant should itself be emitted immediately")
+	}
+
+	if len(emitted) != 1 {
+		t.Fatalf("expected eviction to flush exactly one record, got %d: %v", len(emitted), emitted)
+	}
+	if want := "old message (repeated 5 times)"; emitted[0].Message != want {
+		t.Fatalf("flushed message = %q, want %q", emitted[0].Message, want)
+	}
+}
+
+// TestDedupFlushLoopFlushesOnTick verifies that suppressed repeats are
+// flushed by the background ticker even without a new key arriving to
+// evict the slot.
+func TestDedupFlushLoopFlushesOnTick(t *testing.T) {
+	flushed := make(chan Record, 1)
+	d := newDedup(DedupConfig{Tick: 10 * time.Millisecond}, func(rec Record) {
+		flushed <- rec
+	})
+	defer d.close()
+
+	d.observe(WARN, "caller", "key", "uh oh", nil)
+	d.observe(WARN, "caller", "key", "uh oh", nil)
+
+	select {
+	case rec := <-flushed:
+		if want := "uh oh (repeated 1 times)"; rec.Message != want {
+			t.Fatalf("flushed message = %q, want %q", rec.Message, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the tick loop to flush the suppressed repeat")
+	}
+}
+
+// TestDedupCloseFlushesPending verifies that Close flushes any
+// suppressed repeats still held in a slot rather than dropping them.
+func TestDedupCloseFlushesPending(t *testing.T) {
+	var emitted []Record
+	d := newDedup(DedupConfig{Tick: time.Hour}, func(rec Record) {
+		emitted = append(emitted, rec)
+	})
+
+	d.observe(ERROR, "caller", "key", "failing", nil)
+	d.observe(ERROR, "caller", "key", "failing", nil)
+
+	d.close()
+
+	if len(emitted) != 1 {
+		t.Fatalf("expected Close to flush exactly one record, got %d: %v", len(emitted), emitted)
+	}
+	if want := "failing (repeated 1 times)"; emitted[0].Message != want {
+		t.Fatalf("flushed message = %q, want %q", emitted[0].Message, want)
+	}
+}
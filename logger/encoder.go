@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"logger/sink"
+)
+
+// Field, Record and Encoder are aliases of the sink package's types, so
+// that an Encoder implemented here (TextEncoder, LogfmtEncoder,
+// JSONEncoder) can be handed directly to a sink.Console, sink.File, etc.
+type (
+	Field   = sink.Field
+	Record  = sink.Record
+	Encoder = sink.Encoder
+)
+
+// TextEncoder renders records in this package's original human-readable
+// format: "TIMESTAMP [LEVEL] caller - message key=value ...".
+type TextEncoder struct{}
+
+// Encode implements Encoder.
+func (TextEncoder) Encode(rec Record) string {
+	msg := rec.Message
+	if len(rec.Fields) > 0 {
+		msg = msg + " " + formatFieldsText(rec.Fields)
+	}
+	return fmt.Sprintf("%s [%s] %s - %s",
+		rec.Timestamp.Format(time.RFC3339), levelToString(LogLevel(rec.Level)), rec.Caller, msg)
+}
+
+func formatFieldsText(fields []Field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s=%v", f.Key, f.Value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// LogfmtEncoder renders records as logfmt:
+// ts=... level=info caller=foo.go:12 msg="..." key=value
+type LogfmtEncoder struct{}
+
+// Encode implements Encoder.
+func (LogfmtEncoder) Encode(rec Record) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ts=%s level=%s caller=%s msg=%s",
+		rec.Timestamp.Format(time.RFC3339), strings.ToLower(levelToString(LogLevel(rec.Level))), rec.Caller, logfmtValue(rec.Message))
+	for _, f := range rec.Fields {
+		fmt.Fprintf(&b, " %s=%s", f.Key, logfmtValue(f.Value))
+	}
+	return b.String()
+}
+
+func logfmtValue(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		s = fmt.Sprintf("%v", v)
+	}
+	if strings.ContainsAny(s, " \"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// JSONEncoder renders records as a single JSON object per line.
+type JSONEncoder struct{}
+
+// Encode implements Encoder.
+func (JSONEncoder) Encode(rec Record) string {
+	obj := make(map[string]any, len(rec.Fields)+4)
+	for _, f := range rec.Fields {
+		obj[f.Key] = f.Value
+	}
+	// Set after the user fields so a field named e.g. "level" can't
+	// clobber the record's real metadata in the emitted line.
+	obj["ts"] = rec.Timestamp.Format(time.RFC3339)
+	obj["level"] = levelToString(LogLevel(rec.Level))
+	obj["caller"] = rec.Caller
+	obj["msg"] = rec.Message
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Sprintf(`{"level":"error","msg":"failed to encode log record: %s"}`, err)
+	}
+	return string(b)
+}
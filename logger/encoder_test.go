@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"logger/sink"
+)
+
+func sampleRecord() Record {
+	return Record{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     sink.Level(INFO),
+		Caller:    "foo.go:12",
+		Message:   "hello",
+		Fields:    []Field{{Key: "user", Value: "ada"}},
+	}
+}
+
+func TestTextEncoder(t *testing.T) {
+	got := TextEncoder{}.Encode(sampleRecord())
+	want := "2024-01-02T03:04:05Z [INFO] foo.go:12 - hello user=ada"
+	if got != want {
+		t.Fatalf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestLogfmtEncoder(t *testing.T) {
+	got := LogfmtEncoder{}.Encode(sampleRecord())
+	want := `ts=2024-01-02T03:04:05Z level=info caller=foo.go:12 msg=hello user=ada`
+	if got != want {
+		t.Fatalf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestLogfmtEncoderQuotesValuesNeedingIt(t *testing.T) {
+	rec := sampleRecord()
+	rec.Message = "hello world"
+	rec.Fields = []Field{{Key: "query", Value: `a="b"`}}
+
+	got := LogfmtEncoder{}.Encode(rec)
+	if !strings.Contains(got, `msg="hello world"`) {
+		t.Fatalf("expected msg with a space to be quoted, got %q", got)
+	}
+	if !strings.Contains(got, `query="a=\"b\""`) {
+		t.Fatalf("expected query value to be quoted and escaped, got %q", got)
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	got := JSONEncoder{}.Encode(sampleRecord())
+
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(got), &obj); err != nil {
+		t.Fatalf("Encode() produced invalid JSON: %v (%q)", err, got)
+	}
+
+	if obj["level"] != "INFO" {
+		t.Fatalf("level = %v, want INFO", obj["level"])
+	}
+	if obj["caller"] != "foo.go:12" {
+		t.Fatalf("caller = %v, want foo.go:12", obj["caller"])
+	}
+	if obj["msg"] != "hello" {
+		t.Fatalf("msg = %v, want hello", obj["msg"])
+	}
+	if obj["user"] != "ada" {
+		t.Fatalf("user = %v, want ada", obj["user"])
+	}
+}
+
+// TestJSONEncoderFieldCannotClobberReservedKeys verifies that a user
+// field sharing a name with one of the record's own keys (ts, level,
+// caller, msg) cannot overwrite that key in the emitted JSON; the
+// record's real metadata always wins.
+func TestJSONEncoderFieldCannotClobberReservedKeys(t *testing.T) {
+	rec := sampleRecord()
+	rec.Fields = []Field{{Key: "level", Value: "forged"}}
+
+	got := JSONEncoder{}.Encode(rec)
+
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(got), &obj); err != nil {
+		t.Fatalf("Encode() produced invalid JSON: %v (%q)", err, got)
+	}
+	if obj["level"] != "INFO" {
+		t.Fatalf("level = %v, want the record's real level INFO, not the forged field value", obj["level"])
+	}
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"logger/sink"
+)
+
+// SetLevel updates the minimum level, including on every sink sharing
+// this logger's LevelGate. Safe to call concurrently with logging calls.
+func (l *Logger) SetLevel(level LogLevel) {
+	l.minLevel.Store(sink.Level(level))
+}
+
+// Level returns the current minimum level.
+func (l *Logger) Level() LogLevel {
+	return LogLevel(l.minLevel.Load())
+}
+
+// cycleLevel shifts the minimum level by delta, clamped to [DEBUG, FATAL].
+func (l *Logger) cycleLevel(delta int32) {
+	next := int32(l.Level()) + delta
+	if next < int32(DEBUG) {
+		next = int32(DEBUG)
+	}
+	if next > int32(FATAL) {
+		next = int32(FATAL)
+	}
+	l.minLevel.Store(sink.Level(next))
+}
+
+// levelPayload is the JSON body accepted and returned by LevelHandler.
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an http.Handler for runtime level control: GET
+// reports the current level as {"level":"info"}, PUT with the same body
+// shape changes it.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			l.writeLevel(w)
+		case http.MethodPut:
+			var payload levelPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, ok := parseLevel(payload.Level)
+			if !ok {
+				http.Error(w, fmt.Sprintf("unknown level %q", payload.Level), http.StatusBadRequest)
+				return
+			}
+			l.SetLevel(level)
+			l.writeLevel(w)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (l *Logger) writeLevel(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(levelPayload{Level: strings.ToLower(levelToString(l.Level()))})
+}
+
+// parseLevel parses a level name as accepted by LevelHandler, matching
+// levelToString case-insensitively.
+func parseLevel(s string) (LogLevel, bool) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return DEBUG, true
+	case "info":
+		return INFO, true
+	case "warn", "warning":
+		return WARN, true
+	case "error":
+		return ERROR, true
+	case "fatal":
+		return FATAL, true
+	default:
+		return 0, false
+	}
+}
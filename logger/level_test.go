@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestLogger(t *testing.T, minLevel LogLevel) *Logger {
+	t.Helper()
+	l, err := NewLogger(LoggerOptions{MinLevel: minLevel, UseSyslog: false})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func TestSetLevelAndLevel(t *testing.T) {
+	l := newTestLogger(t, INFO)
+
+	if got := l.Level(); got != INFO {
+		t.Fatalf("Level() = %v, want INFO", got)
+	}
+
+	l.SetLevel(ERROR)
+	if got := l.Level(); got != ERROR {
+		t.Fatalf("Level() = %v after SetLevel(ERROR), want ERROR", got)
+	}
+}
+
+func TestCycleLevel(t *testing.T) {
+	l := newTestLogger(t, INFO)
+
+	l.cycleLevel(-1)
+	if got := l.Level(); got != DEBUG {
+		t.Fatalf("Level() = %v after cycleLevel(-1) from INFO, want DEBUG", got)
+	}
+
+	// Clamped at the floor.
+	l.cycleLevel(-1)
+	if got := l.Level(); got != DEBUG {
+		t.Fatalf("Level() = %v after cycling below DEBUG, want it clamped to DEBUG", got)
+	}
+
+	l.SetLevel(FATAL)
+	l.cycleLevel(1)
+	if got := l.Level(); got != FATAL {
+		t.Fatalf("Level() = %v after cycling above FATAL, want it clamped to FATAL", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    LogLevel
+		wantOK  bool
+		altName bool
+	}{
+		{"debug", DEBUG, true, false},
+		{"INFO", INFO, true, false},
+		{"warn", WARN, true, false},
+		{"warning", WARN, true, true},
+		{"error", ERROR, true, false},
+		{"fatal", FATAL, true, false},
+		{"bogus", 0, false, false},
+	}
+
+	for _, c := range cases {
+		got, ok := parseLevel(c.in)
+		if ok != c.wantOK {
+			t.Errorf("parseLevel(%q) ok = %v, want %v", c.in, ok, c.wantOK)
+			continue
+		}
+		if ok && got != c.want {
+			t.Errorf("parseLevel(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestLevelHandlerGet(t *testing.T) {
+	l := newTestLogger(t, WARN)
+
+	req := httptest.NewRequest(http.MethodGet, "/level", nil)
+	rec := httptest.NewRecorder()
+	l.LevelHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var payload levelPayload
+	if err := json.NewDecoder(rec.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Level != "warn" {
+		t.Fatalf("level = %q, want %q", payload.Level, "warn")
+	}
+}
+
+func TestLevelHandlerPut(t *testing.T) {
+	l := newTestLogger(t, INFO)
+
+	body, _ := json.Marshal(levelPayload{Level: "debug"})
+	req := httptest.NewRequest(http.MethodPut, "/level", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	l.LevelHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := l.Level(); got != DEBUG {
+		t.Fatalf("Level() = %v after PUT debug, want DEBUG", got)
+	}
+}
+
+func TestLevelHandlerPutUnknownLevel(t *testing.T) {
+	l := newTestLogger(t, INFO)
+
+	body, _ := json.Marshal(levelPayload{Level: "verbose"})
+	req := httptest.NewRequest(http.MethodPut, "/level", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	l.LevelHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+	if got := l.Level(); got != INFO {
+		t.Fatalf("Level() = %v after rejected PUT, want unchanged INFO", got)
+	}
+}
+
+func TestLevelHandlerMethodNotAllowed(t *testing.T) {
+	l := newTestLogger(t, INFO)
+
+	req := httptest.NewRequest(http.MethodPost, "/level", nil)
+	rec := httptest.NewRecorder()
+	l.LevelHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", rec.Code)
+	}
+	if got := rec.Header().Get("Allow"); got != "GET, PUT" {
+		t.Fatalf("Allow header = %q, want %q", got, "GET, PUT")
+	}
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"hash/maphash"
+	"time"
+
+	"sync/atomic"
+)
+
+// samplerRingSize bounds the number of distinct (level, caller, format)
+// keys tracked concurrently; keys hash into this small fixed-size ring
+// rather than a map, so the hot path never allocates or locks. Two keys
+// landing in the same slot share a counter, which only makes sampling
+// marginally more aggressive for the collision.
+const samplerRingSize = 2048
+
+// SamplerConfig configures per-(level, caller, format) rate limiting:
+// the first Initial log lines within a Tick window pass through, then
+// only 1 in every Thereafter.
+type SamplerConfig struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+type samplerCounter struct {
+	tickStart atomic.Int64
+	hits      atomic.Int64
+}
+
+// sampler implements SamplerConfig's throttling.
+type sampler struct {
+	initial    int64
+	thereafter int64
+	tick       int64 // nanoseconds
+	seed       maphash.Seed
+	counters   [samplerRingSize]samplerCounter
+}
+
+func newSampler(cfg SamplerConfig) *sampler {
+	tick := cfg.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	thereafter := int64(cfg.Thereafter)
+	if thereafter <= 0 {
+		thereafter = 1
+	}
+
+	return &sampler{
+		initial:    int64(cfg.Initial),
+		thereafter: thereafter,
+		tick:       int64(tick),
+		seed:       maphash.MakeSeed(),
+	}
+}
+
+// allow reports whether the line identified by key should be emitted in
+// the current tick window.
+func (s *sampler) allow(key string) bool {
+	c := &s.counters[s.bucketIndex(key)]
+
+	now := time.Now().UnixNano()
+	tickStart := now - now%s.tick
+
+	if c.tickStart.Swap(tickStart) != tickStart {
+		c.hits.Store(0)
+	}
+
+	n := c.hits.Add(1)
+	if n <= s.initial {
+		return true
+	}
+	return (n-s.initial)%s.thereafter == 0
+}
+
+func (s *sampler) bucketIndex(key string) uint64 {
+	var h maphash.Hash
+	h.SetSeed(s.seed)
+	h.WriteString(key)
+	return h.Sum64() % samplerRingSize
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSamplerAllowsInitialThenThrottles verifies the Initial/Thereafter
+// throttling: the first Initial hits in a tick pass, then only every
+// Thereafter-th hit after that.
+func TestSamplerAllowsInitialThenThrottles(t *testing.T) {
+	s := newSampler(SamplerConfig{Initial: 2, Thereafter: 3, Tick: time.Hour})
+
+	got := make([]bool, 8)
+	for i := range got {
+		got[i] = s.allow("same-key")
+	}
+
+	want := []bool{true, true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("hit %d: allow() = %v, want %v (full: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+// TestSamplerKeysAreIndependent verifies that distinct keys are
+// tracked separately rather than sharing one counter.
+func TestSamplerKeysAreIndependent(t *testing.T) {
+	s := newSampler(SamplerConfig{Initial: 1, Thereafter: 100, Tick: time.Hour})
+
+	// Keys that land in the same ring slot share a counter by design, so
+	// pick a second key whose slot differs from key a's.
+	b := "b"
+	for s.bucketIndex(b) == s.bucketIndex("a") {
+		b += "b"
+	}
+
+	if !s.allow("a") {
+		t.Fatal("first hit for key a should be allowed")
+	}
+	if !s.allow(b) {
+		t.Fatal("first hit for key b should be allowed, independent of key a's counter")
+	}
+}
+
+// TestSamplerResetsOnNewTick verifies that a key's counter resets once
+// the tick window rolls over, rather than throttling forever.
+func TestSamplerResetsOnNewTick(t *testing.T) {
+	s := newSampler(SamplerConfig{Initial: 1, Thereafter: 100, Tick: 10 * time.Millisecond})
+
+	if !s.allow("key") {
+		t.Fatal("first hit should be allowed")
+	}
+	if s.allow("key") {
+		t.Fatal("second hit within the same tick should be throttled")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if !s.allow("key") {
+		t.Fatal("first hit in a new tick window should be allowed again")
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// installSignalLevelControl cycles the logger's minimum level down on
+// SIGUSR1 (more verbose) and up on SIGUSR2 (less verbose), so operators
+// running a long-lived daemon can enable DEBUG temporarily without a
+// restart. It registers l.core.stopSignal so Close can undo the registration
+// and stop the goroutine instead of leaking both for the life of the
+// process.
+func (l *Logger) installSignalLevelControl() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+	done := make(chan struct{})
+
+	l.core.wg.Add(1)
+	go func() {
+		defer l.core.wg.Done()
+		for {
+			select {
+			case sig := <-ch:
+				switch sig {
+				case syscall.SIGUSR1:
+					l.cycleLevel(-1)
+				case syscall.SIGUSR2:
+					l.cycleLevel(1)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	l.core.stopSignal = func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package logger
+
+// installSignalLevelControl is a no-op on Windows, which has no
+// SIGUSR1/SIGUSR2 equivalent. Use LevelHandler for remote level control
+// instead.
+func (l *Logger) installSignalLevelControl() {}
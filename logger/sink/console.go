@@ -0,0 +1,44 @@
+package sink
+
+import (
+	"io"
+	"os"
+)
+
+// ConsoleConfig configures a Console sink.
+type ConsoleConfig struct {
+	// MinLevel is consulted on every Write via Load(), so it can be a
+	// *LevelGate shared with the parent Logger and change at runtime.
+	MinLevel *LevelGate
+	Encoder  Encoder
+	// Writer defaults to os.Stderr.
+	Writer io.Writer
+}
+
+// Console writes encoded records to an io.Writer, typically os.Stderr.
+type Console struct {
+	cfg ConsoleConfig
+}
+
+// NewConsole returns a Console sink for cfg.
+func NewConsole(cfg ConsoleConfig) *Console {
+	if cfg.Writer == nil {
+		cfg.Writer = os.Stderr
+	}
+	return &Console{cfg: cfg}
+}
+
+// Write implements Sink.
+func (c *Console) Write(rec Record) error {
+	if rec.Level < c.cfg.MinLevel.Load() {
+		return nil
+	}
+	_, err := io.WriteString(c.cfg.Writer, c.cfg.Encoder.Encode(rec)+"\n")
+	return err
+}
+
+// Close implements Sink. Console does not own its writer's lifecycle, so
+// this is a no-op.
+func (c *Console) Close() error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package sink
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type stubEncoder struct{}
+
+func (stubEncoder) Encode(rec Record) string { return rec.Message }
+
+// TestConsoleLevelGateIsDynamic verifies that a Console sink filters
+// against whatever level its LevelGate currently holds, not a value
+// frozen when the sink was constructed.
+func TestConsoleLevelGateIsDynamic(t *testing.T) {
+	gate := NewLevelGate(INFO)
+	var buf bytes.Buffer
+	c := NewConsole(ConsoleConfig{MinLevel: gate, Encoder: stubEncoder{}, Writer: &buf})
+
+	if err := c.Write(Record{Level: DEBUG, Message: "should be filtered"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected DEBUG record to be filtered at MinLevel INFO, got %q", buf.String())
+	}
+
+	gate.Store(DEBUG)
+
+	if err := c.Write(Record{Level: DEBUG, Message: "should pass"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "should pass") {
+		t.Fatalf("expected DEBUG record to pass after lowering the shared gate to DEBUG, got %q", buf.String())
+	}
+}
+
+// TestLevelGateNilIsDebug verifies a zero-value (nil) *LevelGate behaves
+// like the zero Level, so a Config built as a bare struct literal keeps
+// its pre-LevelGate behavior of accepting everything.
+func TestLevelGateNilIsDebug(t *testing.T) {
+	var gate *LevelGate
+	if gate.Load() != DEBUG {
+		t.Fatalf("nil *LevelGate.Load() = %v, want DEBUG", gate.Load())
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+
+package sink
+
+import "fmt"
+
+// EventlogConfig configures an Eventlog sink. Eventlog is only available
+// on Windows; see eventlog_windows.go.
+type EventlogConfig struct {
+	// MinLevel is consulted on every Write via Load(), so it can be a
+	// *LevelGate shared with the parent Logger and change at runtime.
+	MinLevel *LevelGate
+	Encoder  Encoder
+	Source   string
+}
+
+// Eventlog is unavailable on this platform; NewEventlog always fails.
+type Eventlog struct{}
+
+// NewEventlog always returns an error on non-Windows platforms.
+func NewEventlog(cfg EventlogConfig) (*Eventlog, error) {
+	return nil, fmt.Errorf("sink: eventlog is only supported on windows")
+}
+
+// Write implements Sink.
+func (e *Eventlog) Write(rec Record) error {
+	return nil
+}
+
+// Close implements Sink.
+func (e *Eventlog) Close() error {
+	return nil
+}
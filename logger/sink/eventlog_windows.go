@@ -0,0 +1,64 @@
+//go:build windows
+
+package sink
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// EventlogConfig configures an Eventlog sink.
+type EventlogConfig struct {
+	// MinLevel is consulted on every Write via Load(), so it can be a
+	// *LevelGate shared with the parent Logger and change at runtime.
+	MinLevel *LevelGate
+	Encoder  Encoder
+	// Source is the Event Log source name to register and log under.
+	Source string
+}
+
+// Eventlog writes records to the Windows Event Log under Source,
+// registering the source on first use and mapping our levels to
+// Information, Warning and Error.
+type Eventlog struct {
+	cfg EventlogConfig
+	log *eventlog.Log
+}
+
+// NewEventlog registers cfg.Source if needed and returns a ready sink.
+func NewEventlog(cfg EventlogConfig) (*Eventlog, error) {
+	if err := eventlog.InstallAsEventCreate(cfg.Source, eventlog.Info|eventlog.Warning|eventlog.Error); err != nil && !strings.Contains(err.Error(), "already exists") {
+		return nil, fmt.Errorf("sink: register eventlog source %s: %w", cfg.Source, err)
+	}
+
+	l, err := eventlog.Open(cfg.Source)
+	if err != nil {
+		return nil, fmt.Errorf("sink: open eventlog source %s: %w", cfg.Source, err)
+	}
+
+	return &Eventlog{cfg: cfg, log: l}, nil
+}
+
+// Write implements Sink.
+func (e *Eventlog) Write(rec Record) error {
+	if rec.Level < e.cfg.MinLevel.Load() {
+		return nil
+	}
+
+	line := e.cfg.Encoder.Encode(rec)
+	switch {
+	case rec.Level >= ERROR:
+		return e.log.Error(1, line)
+	case rec.Level >= WARN:
+		return e.log.Warning(1, line)
+	default:
+		return e.log.Info(1, line)
+	}
+}
+
+// Close implements Sink.
+func (e *Eventlog) Close() error {
+	return e.log.Close()
+}
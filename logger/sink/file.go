@@ -0,0 +1,191 @@
+package sink
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileConfig configures a File sink with size- and/or time-based rotation.
+type FileConfig struct {
+	// MinLevel is consulted on every Write via Load(), so it can be a
+	// *LevelGate shared with the parent Logger and change at runtime.
+	MinLevel *LevelGate
+	Encoder  Encoder
+
+	// Path is the active log file path.
+	Path string
+	// MaxSizeBytes rotates the file before the next write once it has
+	// reached this size; the write that crosses the limit still goes to
+	// the current file. Zero disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAge rotates the file once it has been open longer than this
+	// duration. Zero disables time-based rotation.
+	MaxAge time.Duration
+	// MaxBackups is the number of gzip-compressed rolled files to retain;
+	// older ones are deleted. Zero keeps them all.
+	MaxBackups int
+}
+
+// File is a Sink that writes to a local file, rotating and
+// gzip-compressing rolled files similarly to lumberjack.
+type File struct {
+	cfg      FileConfig
+	mu       sync.Mutex
+	f        *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewFile opens (creating if necessary) cfg.Path and returns a ready sink.
+func NewFile(cfg FileConfig) (*File, error) {
+	f := &File{cfg: cfg}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("sink: open %s: %w", f.cfg.Path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("sink: stat %s: %w", f.cfg.Path, err)
+	}
+
+	f.f = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	return nil
+}
+
+// Write implements Sink.
+func (f *File) Write(rec Record) error {
+	if rec.Level < f.cfg.MinLevel.Load() {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.shouldRotate() {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	line := f.cfg.Encoder.Encode(rec) + "\n"
+	n, err := f.f.WriteString(line)
+	f.size += int64(n)
+	return err
+}
+
+func (f *File) shouldRotate() bool {
+	if f.cfg.MaxSizeBytes > 0 && f.size >= f.cfg.MaxSizeBytes {
+		return true
+	}
+	if f.cfg.MaxAge > 0 && time.Since(f.openedAt) >= f.cfg.MaxAge {
+		return true
+	}
+	return false
+}
+
+// rotate closes the active file, gzip-compresses it under a
+// timestamp-suffixed name, opens a fresh file at the original path, and
+// enforces MaxBackups.
+//
+// f.open is attempted unconditionally, even when closing or rolling the
+// old file failed: a transient failure in either of those steps must
+// not leave f holding a closed handle, which would silently fail every
+// future Write until the process restarts.
+func (f *File) rotate() error {
+	closeErr := f.f.Close()
+
+	rolled := fmt.Sprintf("%s.%s", f.cfg.Path, time.Now().Format("20060102T150405"))
+	rollErr := f.roll(rolled)
+
+	openErr := f.open()
+
+	switch {
+	case closeErr != nil:
+		return fmt.Errorf("sink: close %s for rotation: %w", f.cfg.Path, closeErr)
+	case rollErr != nil:
+		return rollErr
+	case openErr != nil:
+		return openErr
+	}
+
+	return f.pruneBackups()
+}
+
+// roll renames the active file to rolled and gzip-compresses it.
+func (f *File) roll(rolled string) error {
+	if err := os.Rename(f.cfg.Path, rolled); err != nil {
+		return fmt.Errorf("sink: rotate %s: %w", f.cfg.Path, err)
+	}
+	return gzipAndRemove(rolled)
+}
+
+func gzipAndRemove(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("sink: open rolled file %s: %w", path, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return fmt.Errorf("sink: create %s.gz: %w", path, err)
+	}
+	defer out.Close()
+
+	gw := gzip.NewWriter(out)
+	if _, err := io.Copy(gw, in); err != nil {
+		gw.Close()
+		return fmt.Errorf("sink: compress %s: %w", path, err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("sink: finalize %s.gz: %w", path, err)
+	}
+
+	return os.Remove(path)
+}
+
+func (f *File) pruneBackups() error {
+	if f.cfg.MaxBackups <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(f.cfg.Path + ".*.gz")
+	if err != nil {
+		return fmt.Errorf("sink: list rolled files: %w", err)
+	}
+	if len(matches) <= f.cfg.MaxBackups {
+		return nil
+	}
+
+	sort.Strings(matches) // timestamp suffix sorts chronologically
+	for _, old := range matches[:len(matches)-f.cfg.MaxBackups] {
+		if err := os.Remove(old); err != nil {
+			return fmt.Errorf("sink: prune %s: %w", old, err)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.f.Close()
+}
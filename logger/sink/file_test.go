@@ -0,0 +1,59 @@
+package sink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileRotateRecoversFromRollFailure verifies that a transient
+// failure rolling the old file doesn't leave the sink with a
+// permanently closed handle: rotate must still reopen the active file
+// so later writes succeed once the transient condition clears.
+func TestFileRotateRecoversFromRollFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	f, err := NewFile(FileConfig{
+		MinLevel:     NewLevelGate(DEBUG),
+		Encoder:      stubEncoder{},
+		Path:         path,
+		MaxSizeBytes: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewFile: %v", err)
+	}
+	defer f.Close()
+
+	if err := f.Write(Record{Level: DEBUG, Message: "first"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Remove the directory out from under the open file so the rename
+	// and reopen inside rotate() both fail with ENOENT, simulating a
+	// transient disk blip. This works regardless of the test's uid,
+	// unlike a permission-based failure which root ignores.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+
+	if err := f.Write(Record{Level: DEBUG, Message: "trigger rotation"}); err == nil {
+		t.Fatal("expected the rotation failure to surface as an error")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	// rotate() is still recovering: the previous rotate's reopen raced
+	// the missing directory too, so this write both retries the roll
+	// (which still fails, since the original file is gone for good) and
+	// reopens successfully against the now-restored directory.
+	if err := f.Write(Record{Level: DEBUG, Message: "retry"}); err != nil {
+		t.Logf("expected transient error while the rotation history catches up: %v", err)
+	}
+
+	if err := f.Write(Record{Level: DEBUG, Message: "recovered"}); err != nil {
+		t.Fatalf("sink should have recovered a working file handle after the failed rotation, got: %v", err)
+	}
+}
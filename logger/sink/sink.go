@@ -0,0 +1,87 @@
+// Package sink defines the output destinations a Logger can fan a log
+// record out to: console, rotating file, syslog and (on Windows) the
+// Event Log. Each Sink owns its own minimum level (typically a
+// *LevelGate shared with the parent Logger so runtime level changes
+// reach it) and Encoder, so a single logger can, for example, send
+// DEBUG to a file while only ERROR+ reaches syslog.
+package sink
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Level mirrors the parent logger's severity levels. It is defined
+// independently of package logger to avoid an import cycle; the two
+// share the same DEBUG..FATAL ordering so callers can convert with a
+// plain numeric conversion.
+type Level int
+
+const (
+	DEBUG Level = iota
+	INFO
+	WARN
+	ERROR
+	FATAL
+)
+
+// Field is a single structured logging attribute.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Record is the fully-resolved data for one log line, ready to be
+// encoded and written by a Sink.
+type Record struct {
+	Timestamp time.Time
+	Level     Level
+	Caller    string
+	Message   string
+	Fields    []Field
+}
+
+// Encoder renders a Record as a single line of text.
+type Encoder interface {
+	Encode(rec Record) string
+}
+
+// Sink is a single log output destination. Implementations filter by
+// their own minimum level before writing.
+type Sink interface {
+	// Write emits rec if it meets the sink's minimum level.
+	Write(rec Record) error
+	// Close releases any resources the sink holds.
+	Close() error
+}
+
+// LevelGate is a concurrency-safe minimum level that can be shared
+// between a Logger and the Sinks it constructs. Passing the same
+// *LevelGate into several sink configs lets one call to Logger.SetLevel
+// change what every one of them filters, instead of each sink filtering
+// forever against the value it was handed at construction time. A nil
+// *LevelGate reads as DEBUG, matching the zero value of Level, so a
+// sink built with a bare Config literal still behaves as before.
+type LevelGate struct {
+	v atomic.Int32
+}
+
+// NewLevelGate returns a LevelGate initialized to level.
+func NewLevelGate(level Level) *LevelGate {
+	g := &LevelGate{}
+	g.v.Store(int32(level))
+	return g
+}
+
+// Load returns the current level.
+func (g *LevelGate) Load() Level {
+	if g == nil {
+		return DEBUG
+	}
+	return Level(g.v.Load())
+}
+
+// Store updates the current level.
+func (g *LevelGate) Store(level Level) {
+	g.v.Store(int32(level))
+}
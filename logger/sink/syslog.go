@@ -0,0 +1,171 @@
+package sink
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/syslog"
+	"strings"
+
+	"logger/syslog5424"
+)
+
+// SyslogFormat selects the wire format used by a Syslog sink.
+type SyslogFormat int
+
+const (
+	// RFC3164 is the legacy BSD syslog format.
+	RFC3164 SyslogFormat = iota
+	// RFC5424 carries structured data and supports TCP/TLS framing.
+	RFC5424
+)
+
+// SyslogConfig configures a Syslog sink.
+type SyslogConfig struct {
+	// MinLevel is consulted on every Write via Load(), so it can be a
+	// *LevelGate shared with the parent Logger and change at runtime.
+	MinLevel *LevelGate
+	// Encoder is only consulted for RFC3164, where fields must be
+	// flattened into the message text. RFC5424 always carries fields as
+	// a structured data element instead.
+	Encoder Encoder
+
+	Format   SyslogFormat
+	Protocol string // "udp" (default), "tcp", or "tls" (RFC5424 only)
+	Addr     string
+	Facility syslog.Priority
+	Tag      string
+
+	// TLSConfig configures the TLS transport when Protocol is "tls".
+	TLSConfig *tls.Config
+}
+
+// Syslog forwards records to a local or remote syslog collector, using
+// either the legacy RFC 3164 format or native RFC 5424 framing.
+type Syslog struct {
+	cfg    SyslogConfig
+	legacy *syslog.Writer
+	native *syslog5424.Writer
+}
+
+// NewSyslog dials the configured syslog transport and returns a ready sink.
+func NewSyslog(cfg SyslogConfig) (*Syslog, error) {
+	protocol := cfg.Protocol
+	if protocol == "" {
+		protocol = "udp"
+	}
+
+	s := &Syslog{cfg: cfg}
+
+	switch cfg.Format {
+	case RFC5424:
+		writer, err := syslog5424.NewWriter(syslog5424.Config{
+			Protocol:  syslog5424.Protocol(protocol),
+			Addr:      cfg.Addr,
+			Facility:  cfg.Facility,
+			Tag:       cfg.Tag,
+			TLSConfig: cfg.TLSConfig,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sink: connect to syslog: %w", err)
+		}
+		s.native = writer
+	default:
+		if protocol == "tls" {
+			return nil, fmt.Errorf("sink: connect to syslog: tls requires Format RFC5424")
+		}
+		writer, err := syslog.Dial(protocol, cfg.Addr, cfg.Facility, cfg.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("sink: connect to syslog: %w", err)
+		}
+		s.legacy = writer
+	}
+
+	return s, nil
+}
+
+// Write implements Sink.
+func (s *Syslog) Write(rec Record) error {
+	if rec.Level < s.cfg.MinLevel.Load() {
+		return nil
+	}
+
+	if s.native != nil {
+		return s.native.Write(levelToSeverity(rec.Level), "", fieldsToSD(rec.Fields), rec.Message)
+	}
+
+	message := flattenFields(rec.Message, rec.Fields)
+	if s.cfg.Encoder != nil {
+		message = s.cfg.Encoder.Encode(rec)
+	}
+	switch rec.Level {
+	case DEBUG:
+		return s.legacy.Debug(message)
+	case INFO:
+		return s.legacy.Info(message)
+	case WARN:
+		return s.legacy.Warning(message)
+	case ERROR:
+		return s.legacy.Err(message)
+	case FATAL:
+		return s.legacy.Crit(message)
+	default:
+		return s.legacy.Info(message)
+	}
+}
+
+// Close implements Sink.
+func (s *Syslog) Close() error {
+	if s.native != nil {
+		return s.native.Close()
+	}
+	if s.legacy != nil {
+		return s.legacy.Close()
+	}
+	return nil
+}
+
+// levelToSeverity maps our Level to the RFC 5424 severity it corresponds to.
+func levelToSeverity(level Level) syslog5424.Severity {
+	switch level {
+	case DEBUG:
+		return syslog5424.SeverityDebug
+	case INFO:
+		return syslog5424.SeverityInfo
+	case WARN:
+		return syslog5424.SeverityWarning
+	case ERROR:
+		return syslog5424.SeverityErr
+	case FATAL:
+		return syslog5424.SeverityCrit
+	default:
+		return syslog5424.SeverityInfo
+	}
+}
+
+// fieldsToSD converts fields into a single RFC 5424 SD-ELEMENT, or nil if
+// there are no fields to carry.
+func fieldsToSD(fields []Field) []syslog5424.SDElement {
+	if len(fields) == 0 {
+		return nil
+	}
+	params := make(map[string]string, len(fields))
+	for _, f := range fields {
+		params[f.Key] = fmt.Sprintf("%v", f.Value)
+	}
+	return []syslog5424.SDElement{{ID: "fields@32473", Params: params}}
+}
+
+// flattenFields appends fields as "key=value" text after message, for
+// transports that cannot carry structured data.
+func flattenFields(message string, fields []Field) string {
+	if len(fields) == 0 {
+		return message
+	}
+	var b strings.Builder
+	b.WriteString(message)
+	for _, f := range fields {
+		b.WriteByte(' ')
+		fmt.Fprintf(&b, "%s=%v", f.Key, f.Value)
+	}
+	return b.String()
+}
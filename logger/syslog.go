@@ -1,13 +1,18 @@
 package logger
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
-	"log"
 	"log/syslog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"logger/sink"
 )
 
 // LogLevel represents the severity of a log message
@@ -21,13 +26,63 @@ const (
 	FATAL
 )
 
-// Logger provides a structured logging interface with syslog support
+// SyslogFormat selects the wire format used when UseSyslog is enabled.
+type SyslogFormat int
+
+const (
+	// RFC3164 is the legacy BSD syslog format (the historical default).
+	RFC3164 SyslogFormat = iota
+	// RFC5424 carries structured data and supports TCP/TLS framing.
+	RFC5424
+)
+
+// loggerCore holds the dispatch state shared by a Logger and every
+// logger derived from it via With(): the sinks, the async queue and
+// worker goroutines, and rate limiting/dedup. It is referenced by
+// pointer so that With() can share all of it in one assignment instead
+// of enumerating each field by hand, which is what let the async queue
+// and the sampler/dedup state fall out of sync with With() when they
+// were added.
+type loggerCore struct {
+	mu    sync.Mutex
+	sinks []sink.Sink
+
+	// async dispatch; see async.go. queue and done are nil unless
+	// LoggerOptions.Async was set.
+	async    bool
+	overflow OverflowPolicy
+	queue    chan queueItem
+	done     chan struct{}
+	wg       sync.WaitGroup
+	dropped  atomic.Int64
+
+	// rate limiting and dedup; see sampler.go and dedup.go. Both nil
+	// unless the corresponding LoggerOptions field was set.
+	sampler *sampler
+	dedup   *dedup
+
+	// stopSignal tears down the SIGUSR1/SIGUSR2 handler installed by
+	// installSignalLevelControl, if any. nil unless
+	// LoggerOptions.SignalLevelControl was set.
+	stopSignal func()
+
+	// closeOnce guards the teardown in Close so a second call (a common
+	// idiom: defer l.Close() plus an explicit Close on an error path) is
+	// a no-op instead of racing Flush against an already-closed done
+	// channel or double-closing it.
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Logger provides a structured logging interface that fans each record
+// out to one or more sinks (console, file, syslog, Windows Eventlog). A
+// logger obtained from With() shares its parent's core, so it dispatches
+// through the same sinks, async queue, rate limiting and minimum level;
+// only its own fields are independent.
 type Logger struct {
-	syslogWriter *syslog.Writer
-	stdLogger    *log.Logger
-	minLevel     LogLevel
-	facility     syslog.Priority
-	useSyslog    bool
+	core     *loggerCore
+	minLevel *sink.LevelGate // shared with every sink built by defaultSinks
+	fields   []Field
 }
 
 // LoggerOptions allows for configuring the logger
@@ -38,6 +93,50 @@ type LoggerOptions struct {
 	SyslogTag  string
 	SyslogIP   string
 	SyslogPort int
+
+	// Format selects RFC3164 (default) or RFC5424 framing.
+	Format SyslogFormat
+	// Protocol is "udp" (default), "tcp" or "tls". TLS requires Format
+	// to be RFC5424.
+	Protocol string
+	// TLSConfig configures the TLS transport when Protocol is "tls".
+	TLSConfig *tls.Config
+
+	// Encoder renders each log record to a line of text. Defaults to
+	// TextEncoder, this package's original human-readable format. It is
+	// only used to build the console/syslog sinks implied by the legacy
+	// fields above; it has no effect when Sinks is set.
+	Encoder Encoder
+
+	// Sinks, if set, replaces the console/syslog sinks that would
+	// otherwise be synthesized from MinLevel, UseSyslog, Format, etc.,
+	// letting callers mix MinLevel and Encoder per destination (e.g.
+	// DEBUG to a rotating file, ERROR+ to syslog).
+	Sinks []sink.Sink
+
+	// Async, if true, enqueues records on a buffered channel drained by a
+	// dedicated goroutine instead of writing to sinks on the caller's
+	// goroutine. Use this behind unreliable syslog endpoints or slow
+	// disks, where a synchronous write could stall the application.
+	Async bool
+	// BufferSize is the queue capacity when Async is set. Defaults to
+	// 1024 if zero.
+	BufferSize int
+	// OverflowPolicy controls what happens when the queue is full.
+	// Defaults to Block.
+	OverflowPolicy OverflowPolicy
+
+	// SignalLevelControl, if true, installs a SIGUSR1/SIGUSR2 handler
+	// (no-op on Windows) that cycles the minimum level down/up without
+	// restarting the process.
+	SignalLevelControl bool
+
+	// Sampler, if set, rate-limits repeated log lines per (level, caller,
+	// format string).
+	Sampler *SamplerConfig
+	// Dedup, if set, coalesces identical repeats (same level, caller and
+	// format string) into a single "... (repeated N times)" line.
+	Dedup *DedupConfig
 }
 
 // DefaultOptions provides sensible defaults
@@ -49,36 +148,134 @@ func DefaultOptions() LoggerOptions {
 		SyslogTag:  filepath.Base(os.Args[0]),
 		SyslogIP:   "127.0.0.1",
 		SyslogPort: 514,
+		Format:     RFC3164,
+		Protocol:   "udp",
+		Encoder:    TextEncoder{},
 	}
 }
 
 // NewLogger creates a new logger with the given options
 func NewLogger(opts LoggerOptions) (*Logger, error) {
-	logger := &Logger{
-		stdLogger: log.New(os.Stderr, "", 0),
-		minLevel:  opts.MinLevel,
-		facility:  opts.Facility,
-		useSyslog: opts.UseSyslog,
-	}
+	// gate is handed to every sink defaultSinks builds, so Logger.SetLevel
+	// (and friends) changes what they filter, not just the early-exit
+	// check in log()/logw(). Callers supplying opts.Sinks directly own
+	// their own sinks' level wiring.
+	gate := sink.NewLevelGate(sink.Level(opts.MinLevel))
+
+	sinks := opts.Sinks
+	if len(sinks) == 0 {
+		encoder := opts.Encoder
+		if encoder == nil {
+			encoder = TextEncoder{}
+		}
 
-	if opts.UseSyslog {
-		syslogAddr := fmt.Sprintf("%s:%d", opts.SyslogIP, opts.SyslogPort)
-		writer, err := syslog.Dial("udp", syslogAddr, opts.Facility, opts.SyslogTag)
+		built, err := defaultSinks(opts, encoder, gate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to syslog: %v", err)
+			return nil, err
 		}
-		logger.syslogWriter = writer
+		sinks = built
+	}
+
+	l := &Logger{
+		core:     &loggerCore{sinks: sinks},
+		minLevel: gate,
 	}
 
-	return logger, nil
+	if opts.Async {
+		l.startAsync(opts.BufferSize, opts.OverflowPolicy)
+	}
+
+	if opts.SignalLevelControl {
+		l.installSignalLevelControl()
+	}
+
+	if opts.Sampler != nil {
+		l.core.sampler = newSampler(*opts.Sampler)
+	}
+
+	if opts.Dedup != nil {
+		l.core.dedup = newDedup(*opts.Dedup, l.emit)
+	}
+
+	return l, nil
+}
+
+// defaultSinks builds the console (and, if enabled, syslog) sinks implied
+// by the legacy options, for callers that have not set Sinks explicitly.
+// gate is shared with the Logger so its dynamic level reaches both sinks.
+func defaultSinks(opts LoggerOptions, encoder Encoder, gate *sink.LevelGate) ([]sink.Sink, error) {
+	sinks := []sink.Sink{sink.NewConsole(sink.ConsoleConfig{
+		MinLevel: gate,
+		Encoder:  encoder,
+	})}
+
+	if !opts.UseSyslog {
+		return sinks, nil
+	}
+
+	syslogSink, err := sink.NewSyslog(sink.SyslogConfig{
+		MinLevel:  gate,
+		Encoder:   encoder,
+		Format:    sink.SyslogFormat(opts.Format),
+		Protocol:  opts.Protocol,
+		Addr:      fmt.Sprintf("%s:%d", opts.SyslogIP, opts.SyslogPort),
+		Facility:  opts.Facility,
+		Tag:       opts.SyslogTag,
+		TLSConfig: opts.TLSConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+
+	return append(sinks, syslogSink), nil
 }
 
-// Close properly closes the logger
+// Close flushes any pending asynchronous records, joins the worker
+// goroutines, and closes all of the logger's sinks, returning the first
+// error encountered, if any. Calling Close more than once (directly, via
+// a deferred call plus an error-path call, or from a With()-derived
+// logger sharing the same core) is safe; only the first call does any
+// work, and every call returns that first call's result.
 func (l *Logger) Close() error {
-	if l.useSyslog && l.syslogWriter != nil {
-		return l.syslogWriter.Close()
+	core := l.core
+	core.closeOnce.Do(func() {
+		core.closeErr = l.closeCore()
+	})
+	return core.closeErr
+}
+
+func (l *Logger) closeCore() error {
+	core := l.core
+
+	if core.dedup != nil {
+		core.dedup.close()
+	}
+
+	if core.stopSignal != nil {
+		core.stopSignal()
+	}
+
+	if core.async {
+		l.Flush(context.Background())
+		// Signal shutdown first; enqueue's Block branch selects on
+		// core.done so a producer racing this Close isn't left sending
+		// on a channel we then close out from under it. The queue
+		// itself is never closed (see async.go), since any send that
+		// arrives after this point would panic instead.
+		close(core.done)
 	}
-	return nil
+	core.wg.Wait()
+
+	core.mu.Lock()
+	defer core.mu.Unlock()
+
+	var firstErr error
+	for _, s := range core.sinks {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 // levelToString converts a LogLevel to its string representation
@@ -108,47 +305,125 @@ func getCallerInfo(skip int) string {
 	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
 }
 
-// log logs a message with the given level
+// log logs a formatted message with the given level
 func (l *Logger) log(level LogLevel, format string, args ...interface{}) {
-	if level < l.minLevel {
+	if level < l.Level() {
 		return
 	}
+	l.write(level, format, fmt.Sprintf(format, args...), nil)
+}
 
-	message := fmt.Sprintf(format, args...)
-	timestamp := time.Now().Format(time.RFC3339)
-	caller := getCallerInfo(3) // skip through our logging stack
-	logLine := fmt.Sprintf("%s [%s] %s - %s", timestamp, levelToString(level), caller, message)
-
-	// Always log to stdout/stderr
-	l.stdLogger.Print(logLine)
-
-	// Log to syslog if enabled
-	if l.useSyslog && l.syslogWriter != nil {
-		var err error
-		switch level {
-		case DEBUG:
-			err = l.syslogWriter.Debug(message)
-		case INFO:
-			err = l.syslogWriter.Info(message)
-		case WARN:
-			err = l.syslogWriter.Warning(message)
-		case ERROR:
-			err = l.syslogWriter.Err(message)
-		case FATAL:
-			err = l.syslogWriter.Crit(message)
-		}
+// logw logs msg along with fields parsed from the alternating kv pairs.
+func (l *Logger) logw(level LogLevel, msg string, kv []any) {
+	if level < l.Level() {
+		return
+	}
+	l.write(level, msg, msg, fieldsFromKV(kv))
+}
 
-		if err != nil {
-			l.stdLogger.Printf("Failed to write to syslog: %v", err)
+// write renders a single log record and fans it out to every sink. It is
+// the single path both the format-string API (Debug, Info, ...) and the
+// structured API (Debugw, Infow, ...) funnel through. formatKey is the
+// format string (or, for the structured API, the message itself) used to
+// key the sampler and dedup, kept separate from message so repeats of
+// the same template with different arguments still dedup together.
+func (l *Logger) write(level LogLevel, formatKey, message string, extra []Field) {
+	caller := getCallerInfo(4) // skip through our logging stack
+
+	var fields []Field
+	if len(l.fields) > 0 || len(extra) > 0 {
+		fields = make([]Field, 0, len(l.fields)+len(extra))
+		fields = append(fields, l.fields...)
+		fields = append(fields, extra...)
+	}
+
+	// Fatal always gets through: suppressing it would also suppress the
+	// os.Exit below.
+	if level != FATAL && (l.core.sampler != nil || l.core.dedup != nil) {
+		key := fmt.Sprintf("%d|%s|%s", level, caller, formatKey)
+		if l.core.sampler != nil && !l.core.sampler.allow(key) {
+			return
+		}
+		if l.core.dedup != nil && !l.core.dedup.observe(level, caller, key, message, fields) {
+			return
 		}
 	}
 
-	// Exit on fatal errors
+	rec := Record{
+		Timestamp: time.Now(),
+		Level:     sink.Level(level),
+		Caller:    caller,
+		Message:   message,
+		Fields:    fields,
+	}
+
+	l.emit(rec)
+
+	// Exit on fatal errors, flushing first so an async record isn't lost
 	if level == FATAL {
+		if l.core.async {
+			l.Flush(context.Background())
+		}
 		os.Exit(1)
 	}
 }
 
+// emit dispatches rec to the sinks, either directly or via the async
+// queue. It is also what a dedup flush calls to deliver a coalesced
+// "repeated N times" record through the logger's normal path.
+func (l *Logger) emit(rec Record) {
+	if l.core.async {
+		l.enqueue(rec)
+	} else {
+		l.dispatch(rec)
+	}
+}
+
+// dispatch writes rec to every sink on the calling goroutine.
+func (l *Logger) dispatch(rec Record) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+
+	for _, s := range l.core.sinks {
+		if err := s.Write(rec); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: sink write failed: %v\n", err)
+		}
+	}
+}
+
+// fieldsFromKV converts alternating key/value arguments into Fields. A key
+// that isn't a string is stringified; a trailing key with no value is
+// recorded under "!BADKEY".
+func fieldsFromKV(kv []any) []Field {
+	fields := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", kv[i])
+		}
+		if i+1 >= len(kv) {
+			fields = append(fields, Field{Key: "!BADKEY", Value: key})
+			break
+		}
+		fields = append(fields, Field{Key: key, Value: kv[i+1]})
+	}
+	return fields
+}
+
+// With returns a child logger that carries kv (parsed the same way as
+// Infow and friends) on every subsequent call, in addition to any fields
+// already carried by l. The child shares l's core and minLevel gate, so
+// it dispatches through the same sinks, async queue, sampler and dedup,
+// and a later SetLevel call (on l or the child) changes both; only its
+// fields are independent.
+func (l *Logger) With(kv ...any) *Logger {
+	return &Logger{
+		core:     l.core,
+		minLevel: l.minLevel,
+		fields:   append(append([]Field{}, l.fields...), fieldsFromKV(kv)...),
+	}
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(format string, args ...interface{}) {
 	l.log(DEBUG, format, args...)
@@ -173,3 +448,29 @@ func (l *Logger) Error(format string, args ...interface{}) {
 func (l *Logger) Fatal(format string, args ...interface{}) {
 	l.log(FATAL, format, args...)
 }
+
+// Debugw logs msg at DEBUG level with the given alternating key/value pairs.
+func (l *Logger) Debugw(msg string, kv ...any) {
+	l.logw(DEBUG, msg, kv)
+}
+
+// Infow logs msg at INFO level with the given alternating key/value pairs.
+func (l *Logger) Infow(msg string, kv ...any) {
+	l.logw(INFO, msg, kv)
+}
+
+// Warnw logs msg at WARN level with the given alternating key/value pairs.
+func (l *Logger) Warnw(msg string, kv ...any) {
+	l.logw(WARN, msg, kv)
+}
+
+// Errorw logs msg at ERROR level with the given alternating key/value pairs.
+func (l *Logger) Errorw(msg string, kv ...any) {
+	l.logw(ERROR, msg, kv)
+}
+
+// Fatalw logs msg at FATAL level with the given alternating key/value pairs,
+// then exits.
+func (l *Logger) Fatalw(msg string, kv ...any) {
+	l.logw(FATAL, msg, kv)
+}
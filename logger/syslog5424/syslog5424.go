@@ -0,0 +1,240 @@
+// Package syslog5424 implements a native RFC 5424 syslog client.
+//
+// Unlike the standard library's log/syslog, which only speaks the legacy
+// BSD format (RFC 3164) over UDP, this package emits RFC 5424 formatted
+// messages with structured data and supports UDP, TCP and TLS transports.
+// TCP and TLS connections are framed with RFC 5425 octet-counting and are
+// transparently reconnected with exponential backoff on write failure.
+package syslog5424
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Severity mirrors the RFC 5424 severity levels (0 = Emergency, 7 = Debug).
+type Severity int
+
+const (
+	SeverityEmerg Severity = iota
+	SeverityAlert
+	SeverityCrit
+	SeverityErr
+	SeverityWarning
+	SeverityNotice
+	SeverityInfo
+	SeverityDebug
+)
+
+// Protocol selects the transport used to reach the syslog collector.
+type Protocol string
+
+const (
+	ProtocolUDP Protocol = "udp"
+	ProtocolTCP Protocol = "tcp"
+	ProtocolTLS Protocol = "tls"
+)
+
+// SDElement is a single RFC 5424 structured data element, e.g.
+// [exampleSDID@32473 iut="3" eventSource="App"].
+type SDElement struct {
+	ID     string
+	Params map[string]string
+}
+
+// Config configures a Writer.
+type Config struct {
+	Protocol  Protocol
+	Addr      string
+	Facility  syslog.Priority
+	Tag       string
+	TLSConfig *tls.Config
+}
+
+// Writer is a connection to a syslog collector that emits RFC 5424 messages.
+type Writer struct {
+	mu       sync.Mutex
+	cfg      Config
+	conn     net.Conn
+	hostname string
+	pid      int
+}
+
+// NewWriter dials the configured transport and returns a ready Writer.
+func NewWriter(cfg Config) (*Writer, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	w := &Writer{
+		cfg:      cfg,
+		hostname: hostname,
+		pid:      os.Getpid(),
+	}
+
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// connect (re)establishes the underlying network connection.
+func (w *Writer) connect() error {
+	var conn net.Conn
+	var err error
+
+	switch w.cfg.Protocol {
+	case ProtocolUDP, "":
+		conn, err = net.Dial("udp", w.cfg.Addr)
+	case ProtocolTCP:
+		conn, err = net.Dial("tcp", w.cfg.Addr)
+	case ProtocolTLS:
+		conn, err = tls.Dial("tcp", w.cfg.Addr, w.cfg.TLSConfig)
+	default:
+		return fmt.Errorf("syslog5424: unsupported protocol %q", w.cfg.Protocol)
+	}
+	if err != nil {
+		return fmt.Errorf("syslog5424: dial %s %s: %w", w.cfg.Protocol, w.cfg.Addr, err)
+	}
+
+	w.mu.Lock()
+	w.conn = conn
+	w.mu.Unlock()
+	return nil
+}
+
+// reconnectWithBackoff retries connect, doubling the delay from 100ms up to
+// a 30s ceiling, until a connection succeeds or 10 attempts have failed,
+// in which case it returns an error. It does not hold w.mu across
+// the retry loop, so a reconnect in progress (up to minutes, at the
+// backoff ceiling) does not block unrelated callers from reading w.conn.
+func (w *Writer) reconnectWithBackoff() error {
+	const maxBackoff = 30 * time.Second
+	backoff := 100 * time.Millisecond
+
+	for attempt := 0; attempt < 10; attempt++ {
+		if err := w.connect(); err == nil {
+			return nil
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	return fmt.Errorf("syslog5424: giving up reconnecting to %s", w.cfg.Addr)
+}
+
+// writeOnce writes b to the current connection, holding w.mu only long
+// enough to read the connection pointer.
+func (w *Writer) writeOnce(b []byte) error {
+	w.mu.Lock()
+	conn := w.conn
+	w.mu.Unlock()
+
+	_, err := conn.Write(b)
+	return err
+}
+
+// Write emits a single RFC 5424 message with the given severity, optional
+// msgid and structured data elements. A write failure on any transport,
+// including UDP, triggers one reconnect-and-retry before the error is
+// returned.
+func (w *Writer) Write(severity Severity, msgid string, sd []SDElement, msg string) error {
+	line := w.format(severity, msgid, sd, msg)
+
+	payload := []byte(line)
+	if w.cfg.Protocol == ProtocolTCP || w.cfg.Protocol == ProtocolTLS {
+		framed := fmt.Sprintf("%d %s", len(line), line)
+		payload = []byte(framed)
+	}
+
+	if err := w.writeOnce(payload); err != nil {
+		if rerr := w.reconnectWithBackoff(); rerr != nil {
+			return rerr
+		}
+		return w.writeOnce(payload)
+	}
+
+	return nil
+}
+
+// format renders msg as an RFC 5424 line:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID key="val" ...] BOM MSG
+func (w *Writer) format(severity Severity, msgid string, sd []SDElement, msg string) string {
+	pri := int(w.cfg.Facility) + int(severity)
+	ts := time.Now().Format(time.RFC3339)
+
+	if msgid == "" {
+		msgid = "-"
+	}
+	tag := w.cfg.Tag
+	if tag == "" {
+		tag = "-"
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s \ufeff%s\n",
+		pri, ts, w.hostname, tag, w.pid, msgid, formatSD(sd), msg)
+}
+
+// formatSD renders structured data elements, or "-" when there are none.
+// SD-PARAMs are rendered in sorted key order so that identical fields
+// produce identical wire output on every call, rather than the random
+// order Go's map iteration would otherwise give.
+func formatSD(sd []SDElement) string {
+	if len(sd) == 0 {
+		return "-"
+	}
+
+	var b strings.Builder
+	for _, el := range sd {
+		b.WriteByte('[')
+		b.WriteString(el.ID)
+
+		keys := make([]string, 0, len(el.Params))
+		for k := range el.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			b.WriteByte(' ')
+			b.WriteString(k)
+			b.WriteString(`="`)
+			b.WriteString(escapeSDValue(el.Params[k]))
+			b.WriteByte('"')
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+// escapeSDValue escapes the characters RFC 5424 requires within a quoted
+// SD-PARAM value: backslash, double-quote and closing bracket.
+func escapeSDValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	v = strings.ReplaceAll(v, `]`, `\]`)
+	return v
+}
+
+// Close closes the underlying connection.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	return w.conn.Close()
+}
@@ -0,0 +1,89 @@
+package syslog5424
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// TestFormatSDSortsParams verifies that SD-PARAMs render in a stable
+// order across calls, rather than the random order Go's map iteration
+// would otherwise produce for the same fields.
+func TestFormatSDSortsParams(t *testing.T) {
+	sd := []SDElement{{
+		ID: "fields@32473",
+		Params: map[string]string{
+			"zebra": "1",
+			"alpha": "2",
+			"mike":  "3",
+		},
+	}}
+
+	want := `[fields@32473 alpha="2" mike="3" zebra="1"]`
+	for i := 0; i < 10; i++ {
+		if got := formatSD(sd); got != want {
+			t.Fatalf("formatSD() = %q, want %q", got, want)
+		}
+	}
+}
+
+// TestReconnectWithBackoffDoesNotHoldLock verifies that a reconnect in
+// progress (which can sleep for up to 30s between attempts) does not
+// hold w.mu for the duration, so unrelated callers reading w.conn are
+// not blocked behind it.
+func TestReconnectWithBackoffDoesNotHoldLock(t *testing.T) {
+	// Nothing listens on this port, so connect() fails immediately with
+	// connection refused on every attempt, and reconnectWithBackoff
+	// spends its time sleeping between attempts rather than dialing.
+	w := &Writer{cfg: Config{Protocol: ProtocolTCP, Addr: "127.0.0.1:1"}}
+
+	go w.reconnectWithBackoff()
+
+	time.Sleep(20 * time.Millisecond)
+
+	acquired := make(chan struct{})
+	go func() {
+		w.mu.Lock()
+		w.mu.Unlock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("w.mu is still held while reconnectWithBackoff is sleeping between attempts")
+	}
+}
+
+// TestWriteReconnectsOnUDP verifies that a write failure on a UDP
+// writer triggers a reconnect-and-retry, the same as TCP/TLS, instead
+// of surfacing the error with no attempt to recover.
+func TestWriteReconnectsOnUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket: %v", err)
+	}
+	defer conn.Close()
+
+	w, err := NewWriter(Config{Protocol: ProtocolUDP, Addr: conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	defer w.Close()
+
+	// Force the next write to fail by swapping in a connection that's
+	// already closed, simulating the collector having dropped it.
+	stale, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	stale.Close()
+
+	w.mu.Lock()
+	w.conn = stale
+	w.mu.Unlock()
+
+	if err := w.Write(SeverityInfo, "", nil, "hello"); err != nil {
+		t.Fatalf("Write should have reconnected and succeeded, got: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+
+	"logger/sink"
+)
+
+// capturingSink records every Record it's given, for asserting on what
+// the structured logging API and With() actually dispatch.
+type capturingSink struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (c *capturingSink) Write(rec Record) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, rec)
+	return nil
+}
+
+func (c *capturingSink) Close() error { return nil }
+
+func (c *capturingSink) last() Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records[len(c.records)-1]
+}
+
+func fieldValue(fields []Field, key string) (any, bool) {
+	for _, f := range fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+func newCapturingLogger(t *testing.T) (*Logger, *capturingSink) {
+	t.Helper()
+	cs := &capturingSink{}
+	l, err := NewLogger(LoggerOptions{
+		MinLevel: DEBUG,
+		Sinks:    []sink.Sink{cs},
+	})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l, cs
+}
+
+func TestStructuredLoggingMethods(t *testing.T) {
+	cases := []struct {
+		name  string
+		log   func(l *Logger, msg string, kv ...any)
+		level LogLevel
+	}{
+		{"Debugw", (*Logger).Debugw, DEBUG},
+		{"Infow", (*Logger).Infow, INFO},
+		{"Warnw", (*Logger).Warnw, WARN},
+		{"Errorw", (*Logger).Errorw, ERROR},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l, cs := newCapturingLogger(t)
+			c.log(l, "something happened", "key", "value")
+
+			rec := cs.last()
+			if rec.Level != sink.Level(c.level) {
+				t.Fatalf("Level = %v, want %v", rec.Level, c.level)
+			}
+			if rec.Message != "something happened" {
+				t.Fatalf("Message = %q, want %q", rec.Message, "something happened")
+			}
+			v, ok := fieldValue(rec.Fields, "key")
+			if !ok || v != "value" {
+				t.Fatalf("fields = %v, want key=value present", rec.Fields)
+			}
+		})
+	}
+}
+
+func TestWithInheritsFields(t *testing.T) {
+	l, cs := newCapturingLogger(t)
+
+	child := l.With("component", "ingest")
+	child.Infow("started", "job", 42)
+
+	rec := cs.last()
+	v, ok := fieldValue(rec.Fields, "component")
+	if !ok || v != "ingest" {
+		t.Fatalf("fields = %v, want component=ingest inherited from With()", rec.Fields)
+	}
+	v, ok = fieldValue(rec.Fields, "job")
+	if !ok || v != 42 {
+		t.Fatalf("fields = %v, want job=42 from the call site", rec.Fields)
+	}
+
+	// The parent itself must not pick up the child's fields.
+	l.Infow("unrelated")
+	rec = cs.last()
+	if _, ok := fieldValue(rec.Fields, "component"); ok {
+		t.Fatalf("parent logger picked up a field (%v) set on a With() child", rec.Fields)
+	}
+}
+
+func TestWithChainsAcrossGenerations(t *testing.T) {
+	l, cs := newCapturingLogger(t)
+
+	grandchild := l.With("a", 1).With("b", 2)
+	grandchild.Infow("event")
+
+	rec := cs.last()
+	for _, key := range []string{"a", "b"} {
+		if _, ok := fieldValue(rec.Fields, key); !ok {
+			t.Fatalf("fields = %v, want both %q and %q carried across chained With() calls", rec.Fields, "a", "b")
+		}
+	}
+}